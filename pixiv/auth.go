@@ -48,15 +48,17 @@ func (api *AppAPI) ForceAuth() (*RespAuth, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	body := limitBody(resp.Body, api.MaxResponseSize)
+	defer body.Close()
 
-	b, err := ioutil.ReadAll(resp.Body)
+	b, err := ioutil.ReadAll(body)
 	if err != nil {
 		return nil, err
 	}
 
 	if resp.StatusCode < 300 && resp.StatusCode >= 200 {
 		r := &RespAuth{}
+		// Not passed to OnDecodeError, since the body contains tokens and e-mail.
 		err := json.Unmarshal(b, r)
 		if err != nil {
 			return nil, fmt.Errorf("pixiv auth: json decode: %w", err)
@@ -0,0 +1,211 @@
+package pixiv
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// BookmarkRecord is a line of bookmarks exported by ExportBookmarks.
+type BookmarkRecord struct {
+	// TIllust for illusts and manga, or TNovel
+	Type     Type     `json:"type"`
+	ID       int      `json:"id"`
+	Restrict Restrict `json:"restrict"`
+	Title    string   `json:"title,omitempty"`
+
+	// Tags are the bookmark tags, only exported with BookmarkTransferOptions.Tags.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// BookmarkTransferOptions defines options of ExportBookmarks and ImportBookmarks.
+type BookmarkTransferOptions struct {
+	// Interval is the time waited between requests. Defaults to 1 second.
+	Interval time.Duration
+
+	// Tags exports bookmark tags by fetching the bookmark detail of every work,
+	// which takes one more request per bookmark.
+	// Without it, bookmark tags are not migrated.
+	Tags bool
+
+	// Progress is called after each bookmark is fetched by ExportBookmarks
+	// or added by ImportBookmarks, with the count of bookmarks done.
+	Progress func(done int, record *BookmarkRecord)
+}
+
+func (o *BookmarkTransferOptions) interval() time.Duration {
+	if o == nil || o.Interval == 0 {
+		return time.Second
+	}
+	return o.Interval
+}
+
+func (o *BookmarkTransferOptions) progress(done int, record *BookmarkRecord) {
+	if o != nil && o.Progress != nil {
+		o.Progress(done, record)
+	}
+}
+
+// ExportBookmarks writes all public and private illust and novel bookmarks
+// of the login user into w in JSON Lines of BookmarkRecord.
+// Each list of bookmarks is written from the oldest to the newest,
+// so that ImportBookmarks adds them in the original order.
+// Deleted and hidden works are skipped, since they cannot be bookmarked again.
+// It returns the count of bookmarks written.
+func (s *UserService) ExportBookmarks(w io.Writer, opts *BookmarkTransferOptions) (int, error) {
+	if s.api.UserID == 0 {
+		_, err := s.api.ForceAuth()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	fetched, written := 0, 0
+	for _, restrict := range []Restrict{RPublic, RPrivate} {
+		for _, t := range []Type{TIllust, TNovel} {
+			// Pixiv returns the newest bookmarks first.
+			recs, err := s.bookmarkRecords(t, restrict, opts, &fetched)
+			if err != nil {
+				return written, err
+			}
+			for i := len(recs) - 1; i >= 0; i-- {
+				err := enc.Encode(recs[i])
+				if err != nil {
+					return written, fmt.Errorf("pixiv: bookmark export: %w", err)
+				}
+				written++
+			}
+		}
+	}
+	return written, nil
+}
+
+// bookmarkRecords fetches all visible bookmarks of type t with restrict, newest first.
+func (s *UserService) bookmarkRecords(t Type, restrict Restrict, opts *BookmarkTransferOptions, fetched *int) ([]*BookmarkRecord, error) {
+	var recs []*BookmarkRecord
+	add := func(id int, title string) error {
+		rec := &BookmarkRecord{Type: t, ID: id, Restrict: restrict, Title: title}
+		if opts != nil && opts.Tags {
+			time.Sleep(opts.interval())
+			var r *RespBookmarkDetail
+			var err error
+			if t == TNovel {
+				r, err = s.api.Novel.BookmarkDetail(id)
+			} else {
+				r, err = s.api.Illust.BookmarkDetail(id)
+			}
+			if err != nil {
+				return err
+			}
+			for _, tag := range r.BookmarkDetail.Tags {
+				if tag.IsRegistered {
+					rec.Tags = append(rec.Tags, tag.Name)
+				}
+			}
+		}
+		recs = append(recs, rec)
+		*fetched++
+		opts.progress(*fetched, rec)
+		return nil
+	}
+
+	if t == TNovel {
+		rn, err := s.BookmarkedNovels(s.api.UserID, restrict, nil)
+		for {
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range rn.Novels {
+				if !n.Visible {
+					continue
+				}
+				if err := add(n.ID, n.Title); err != nil {
+					return nil, err
+				}
+			}
+			if rn.NextURL == "" {
+				return recs, nil
+			}
+			time.Sleep(opts.interval())
+			rn, err = rn.NextNovels()
+		}
+	}
+
+	ri, err := s.BookmarkedIllusts(s.api.UserID, restrict, nil)
+	for {
+		if err != nil {
+			return nil, err
+		}
+		for _, il := range ri.Illusts {
+			if !il.Visible {
+				continue
+			}
+			if err := add(il.ID, il.Title); err != nil {
+				return nil, err
+			}
+		}
+		if ri.NextURL == "" {
+			return recs, nil
+		}
+		time.Sleep(opts.interval())
+		ri, err = ri.NextIllusts()
+	}
+}
+
+// ImportBookmarks adds bookmarks read from r in the format of ExportBookmarks
+// to the login user in the order of lines with their tags,
+// waiting opts.Interval between additions.
+// restrict overrides the restrict of records unless it is "" or RAll.
+// Records without restrict are added to RPublic.
+// It returns the count of bookmarks added before an error,
+// so that the import can be resumed by skipping the records done.
+func (s *UserService) ImportBookmarks(r io.Reader, restrict Restrict, opts *BookmarkTransferOptions) (int, error) {
+	sc := bufio.NewScanner(r)
+	done := 0
+	for line := 1; sc.Scan(); line++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		rec := &BookmarkRecord{}
+		err := json.Unmarshal(sc.Bytes(), rec)
+		if err != nil {
+			return done, fmt.Errorf("pixiv: bookmark import: line %d: %w", line, err)
+		}
+
+		rs := rec.Restrict
+		if restrict != "" && restrict != RAll {
+			rs = restrict
+		}
+		if rs == "" {
+			rs = RPublic
+		}
+		if done > 0 {
+			time.Sleep(opts.interval())
+		}
+		var bopts *AddBookmarkOptions
+		if len(rec.Tags) > 0 {
+			bopts = &AddBookmarkOptions{Tags: rec.Tags}
+		}
+		switch rec.Type {
+		case TIllust, TManga, TUgoira:
+			err = s.api.Illust.AddBookmark(rec.ID, rs, bopts)
+		case TNovel:
+			err = s.api.Novel.AddBookmark(rec.ID, rs, bopts)
+		default:
+			err = fmt.Errorf("unknown type %q", rec.Type)
+		}
+		if err != nil {
+			return done, fmt.Errorf("pixiv: bookmark import: line %d: %w", line, err)
+		}
+		done++
+		opts.progress(done, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return done, fmt.Errorf("pixiv: bookmark import: %w", err)
+	}
+	return done, nil
+}
@@ -0,0 +1,29 @@
+package pixiv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBookmarkTransfer(t *testing.T) {
+	api := getTestAPI(t)
+	opts := &BookmarkTransferOptions{Interval: 500 * time.Millisecond, Tags: true}
+
+	b := &bytes.Buffer{}
+	n, err := api.User.ExportBookmarks(b, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		return
+	}
+
+	first := strings.SplitN(b.String(), "\n", 2)[0]
+	n, err = api.User.ImportBookmarks(strings.NewReader(first), RAll, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(n == 1, n)
+}
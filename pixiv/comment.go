@@ -8,6 +8,11 @@ import (
 // CommentService fetches comments.
 type CommentService service
 
+// CommentsQuery defines url query of illust and novel comments.
+type CommentsQuery struct {
+	Offset int `url:"offset,omitempty"`
+}
+
 // RepliesIllust fetches illust comment replies.
 func (s *CommentService) RepliesIllust(commentID int) (*RespComments, error) {
 	r := &RespComments{api: s.api}
@@ -1,6 +1,11 @@
 package pixiv
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
 
 func TestComment(t *testing.T) {
 	api := getTestAPI(t)
@@ -22,3 +27,37 @@ func TestComment(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCommentsWithOpts(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`{"total_comments":42,"comments":[{"id":1}],"next_url":null}`))
+	}))
+	defer ts.Close()
+
+	api := New()
+	api.BaseURL = ts.URL
+	api.AccessToken = "token"
+
+	for _, get := range []func() (*RespComments, error){
+		func() (*RespComments, error) { return api.Illust.CommentsWithOpts(1, &CommentsQuery{Offset: 30}) },
+		func() (*RespComments, error) { return api.Novel.CommentsWithOpts(1, &CommentsQuery{Offset: 30}) },
+	} {
+		r, err := get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert(query.Get("offset") == "30", query)
+		assert(query.Get("include_total_comments") == "true", query)
+		assert(r.TotalComments == 42 && len(r.Comments) == 1, r.TotalComments, r.Comments)
+	}
+
+	// Offset is omitted without opts.
+	_, err := api.Illust.CommentsWithOpts(1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ok := query["offset"]
+	assert(!ok && query.Get("illust_id") == "1", query)
+}
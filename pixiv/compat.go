@@ -0,0 +1,20 @@
+package pixiv
+
+// This file keeps replaced APIs working as thin wrappers of the new ones,
+// so that users can migrate incrementally.
+// They are marked with "Deprecated:" comments reported by staticcheck and gopls,
+// and will be removed in the next major version.
+
+// Comments fetches comments of the illust.
+//
+// Deprecated: Use CommentsWithOpts instead, which supports starting from an offset.
+func (s *IllustService) Comments(illustID int) (*RespComments, error) {
+	return s.CommentsWithOpts(illustID, nil)
+}
+
+// Comments fetches comments of the novel.
+//
+// Deprecated: Use CommentsWithOpts instead, which supports starting from an offset.
+func (s *NovelService) Comments(novelID int) (*RespComments, error) {
+	return s.CommentsWithOpts(novelID, nil)
+}
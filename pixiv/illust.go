@@ -75,6 +75,21 @@ func (s *IllustService) AddBookmark(illustID int, restrict Restrict, opts *AddBo
 	)
 }
 
+// BookmarkDetail fetches the bookmark restrict and tags of the illust.
+func (s *IllustService) BookmarkDetail(illustID int) (*RespBookmarkDetail, error) {
+	r := &RespBookmarkDetail{}
+	err := s.api.getWithValues(r,
+		s.api.BaseURL+"/v2/illust/bookmark/detail",
+		nil, url.Values{
+			"illust_id": {strconv.Itoa(illustID)},
+		}, "illust: bookmark detail",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // DeleteBookmark deletes illust from public and private bookmark
 func (s *IllustService) DeleteBookmark(illustID int) error {
 	return s.api.postWithValues(nil,
@@ -95,13 +110,15 @@ func (s *IllustService) AddHistory(illustIDs []int) error {
 	)
 }
 
-// Comments fetches comments of the illust.
-func (s *IllustService) Comments(illustID int) (*RespComments, error) {
+// CommentsWithOpts fetches comments of the illust with options.
+// Set opts.Offset to start from a later page without walking the previous ones.
+func (s *IllustService) CommentsWithOpts(illustID int, opts *CommentsQuery) (*RespComments, error) {
 	r := &RespComments{api: s.api}
 	err := s.api.getWithValues(r,
 		s.api.BaseURL+"/v2/illust/comments",
-		nil, url.Values{
-			"illust_id": {strconv.Itoa(illustID)},
+		opts, url.Values{
+			"illust_id":              {strconv.Itoa(illustID)},
+			"include_total_comments": {"true"},
 		}, "illust: comments",
 	)
 	if err != nil {
@@ -14,7 +14,9 @@ func TestBookmarkOps(t *testing.T) {
 		Tags: []string{"ショタ", "正太", "test"},
 	})
 	err = api.Illust.AddHistory([]int{id})
-	_, err = api.Illust.Comments(id)
+	_, err = api.Illust.BookmarkDetail(id)
+	_, err = api.Illust.CommentsWithOpts(id, nil)
+	_, err = api.Illust.CommentsWithOpts(id, &CommentsQuery{Offset: 30})
 	_, err = api.Illust.Detail(id)
 	_, err = api.Illust.NewFromAll(nil)
 	_, err = api.Illust.NewFromFollowings(RPublic)
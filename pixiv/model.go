@@ -66,6 +66,11 @@ func (d Date) Day() int {
 	return 0
 }
 
+// Time parses date into time.Time in UTC
+func (d Date) Time() (time.Time, error) {
+	return time.Parse("2006-01-02", string(d))
+}
+
 // Generated by https://quicktype.io
 
 // Profile is embedded in RespUserDetail
@@ -139,6 +144,7 @@ type Illust struct {
 	} `json:"meta_pages"`
 	TotalView      int  `json:"total_view"`
 	TotalBookmarks int  `json:"total_bookmarks"`
+	TotalComments  int  `json:"total_comments"`
 	IsBookmarked   bool `json:"is_bookmarked"`
 	Visible        bool `json:"visible"`
 	IsMuted        bool `json:"is_muted"`
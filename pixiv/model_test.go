@@ -26,3 +26,11 @@ func TestDate(t *testing.T) {
 	dd := d.Day()
 	assert(dd == 3, d, 3)
 }
+
+func TestDateTime(t *testing.T) {
+	tm, err := NewDate(2020, 4, 3).Time()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(tm.Year() == 2020 && tm.Month() == 4 && tm.Day() == 3, tm)
+}
@@ -0,0 +1,150 @@
+package pixiv
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// KanaMode defines the kana script words are converted into by Normalizer.
+type KanaMode int
+
+// KanaMode values
+const (
+	KanaKeep KanaMode = iota
+	KanaHiragana
+	KanaKatakana
+)
+
+// Half-width katakana from U+FF61 to U+FF9D in full-width.
+const halfWidthKatakana = "。「」、・ヲァィゥェォャュョッーアイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン"
+
+var halfWidthKatakanaTable = []rune(halfWidthKatakana)
+
+// Normalizer normalizes search words before querying.
+// The zero value leaves words unchanged.
+type Normalizer struct {
+	// Width folds full-width ASCII into half-width
+	// and half-width katakana into full-width.
+	Width bool
+
+	// Kana converts hiragana and katakana into one script.
+	Kana KanaMode
+
+	// Romaji maps lower-case romaji words to kana, like "shota" to "ショタ".
+	// Words without an entry are left as is.
+	Romaji map[string]string
+}
+
+// Normalize returns word normalized with the options of n.
+// If Romaji is set, words separated by spaces are looked up one by one
+// and joined with a single space.
+func (n *Normalizer) Normalize(word string) string {
+	if n == nil {
+		return word
+	}
+	if n.Width {
+		word = FoldWidth(word)
+	}
+	if n.Romaji != nil {
+		fs := strings.Fields(word)
+		for i, f := range fs {
+			if k, ok := n.Romaji[strings.ToLower(f)]; ok {
+				fs[i] = k
+			}
+		}
+		word = strings.Join(fs, " ")
+	}
+	switch n.Kana {
+	case KanaHiragana:
+		word = ToHiragana(word)
+	case KanaKatakana:
+		word = ToKatakana(word)
+	}
+	return word
+}
+
+// Variants returns the normalized word followed by its hiragana and katakana forms,
+// with duplicates removed. Searching each of them improves recall of kana tags.
+func (n *Normalizer) Variants(word string) []string {
+	w := n.Normalize(word)
+	vs := []string{w}
+	for _, v := range []string{ToHiragana(w), ToKatakana(w)} {
+		dup := false
+		for _, x := range vs {
+			if x == v {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// FoldWidth converts full-width ASCII and ideographic spaces into half-width,
+// and half-width katakana into full-width, combining voiced sound marks.
+func FoldWidth(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		switch {
+		case r == '　':
+			b.WriteByte(' ')
+		case r >= '！' && r <= '～':
+			b.WriteRune(r - 0xfee0)
+		case r >= '｡' && r <= 'ﾝ':
+			k := halfWidthKatakanaTable[r-0xff61]
+			next, nsize := utf8.DecodeRuneInString(s[i:])
+			switch {
+			case next == 'ﾞ' && k == 'ウ':
+				k = 'ヴ'
+				i += nsize
+			case next == 'ﾞ' && strings.ContainsRune("カキクケコサシスセソタチツテトハヒフヘホ", k):
+				k++
+				i += nsize
+			case next == 'ﾟ' && strings.ContainsRune("ハヒフヘホ", k):
+				k += 2
+				i += nsize
+			}
+			b.WriteRune(k)
+		case r == 'ﾞ':
+			b.WriteRune('゛')
+		case r == 'ﾟ':
+			b.WriteRune('゜')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// ToHiragana converts katakana in s into hiragana.
+// Katakana without a hiragana form like "ヷ" and "ー" are left as is.
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'ァ' && r <= 'ヶ':
+			return r - 0x60
+		case r == 'ヽ' || r == 'ヾ':
+			return r - 0x60
+		}
+		return r
+	}, s)
+}
+
+// ToKatakana converts hiragana in s into katakana.
+func ToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'ぁ' && r <= 'ゖ':
+			return r + 0x60
+		case r == 'ゝ' || r == 'ゞ':
+			return r + 0x60
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,32 @@
+package pixiv
+
+import "testing"
+
+func TestFoldWidth(t *testing.T) {
+	s := FoldWidth("ＦＡＴＥ　ｼｮﾀ ﾊﾟﾝﾀﾞ ｳﾞｧ")
+	assert(s == "FATE ショタ パンダ ヴァ", s)
+}
+
+func TestKana(t *testing.T) {
+	h := ToHiragana("ショタ・ヴァー")
+	assert(h == "しょた・ゔぁー", h)
+
+	k := ToKatakana("しょたゝ")
+	assert(k == "ショタヽ", k)
+}
+
+func TestNormalizer(t *testing.T) {
+	var nn *Normalizer
+	assert(nn.Normalize("ＡＢ") == "ＡＢ")
+
+	n := &Normalizer{
+		Width:  true,
+		Kana:   KanaKatakana,
+		Romaji: map[string]string{"shota": "しょた"},
+	}
+	s := n.Normalize("Ｓｈｏｔａ　 ふうけい")
+	assert(s == "ショタ フウケイ", s)
+
+	vs := (&Normalizer{Width: true}).Variants("ｼｮﾀ")
+	assert(len(vs) == 2 && vs[0] == "ショタ" && vs[1] == "しょた", vs)
+}
@@ -29,6 +29,21 @@ func (s *NovelService) AddBookmark(novelID int, restrict Restrict, opts *AddBook
 	)
 }
 
+// BookmarkDetail fetches the bookmark restrict and tags of the novel.
+func (s *NovelService) BookmarkDetail(novelID int) (*RespBookmarkDetail, error) {
+	r := &RespBookmarkDetail{}
+	err := s.api.getWithValues(r,
+		s.api.BaseURL+"/v2/novel/bookmark/detail",
+		nil, url.Values{
+			"novel_id": {strconv.Itoa(novelID)},
+		}, "novel: bookmark detail",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // DeleteBookmark deletes novel from public and private bookmark
 func (s *NovelService) DeleteBookmark(novelID int) error {
 	return s.api.postWithValues(nil,
@@ -54,13 +69,15 @@ func (s *NovelService) Text(novelID int) (*RespNovelText, error) {
 	return r, nil
 }
 
-// Comments fetches comments of the novel.
-func (s *NovelService) Comments(novelID int) (*RespComments, error) {
+// CommentsWithOpts fetches comments of the novel with options.
+// Set opts.Offset to start from a later page without walking the previous ones.
+func (s *NovelService) CommentsWithOpts(novelID int, opts *CommentsQuery) (*RespComments, error) {
 	r := &RespComments{api: s.api}
 	err := s.api.getWithValues(r,
 		s.api.BaseURL+"/v2/novel/comments",
-		nil, url.Values{
-			"novel_id": {strconv.Itoa(novelID)},
+		opts, url.Values{
+			"novel_id":               {strconv.Itoa(novelID)},
+			"include_total_comments": {"true"},
 		}, "novel: comments",
 	)
 	if err != nil {
@@ -10,7 +10,9 @@ func TestNovel(t *testing.T) {
 		Tags: []string{"ショタ", "正太", "test"},
 	})
 	err = api.Novel.AddHistory([]int{id})
-	_, err = api.Novel.Comments(id)
+	_, err = api.Novel.BookmarkDetail(id)
+	_, err = api.Novel.CommentsWithOpts(id, nil)
+	_, err = api.Novel.CommentsWithOpts(id, &CommentsQuery{Offset: 30})
 	_, err = api.Novel.Detail(id)
 	_, err = api.Novel.Text(id)
 	_, err = api.Novel.Recommended(nil)
@@ -0,0 +1,181 @@
+package pixiv
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// NovelIndex is an in-memory inverted index of novel texts for keyword search.
+// It is safe for concurrent use.
+//
+// Latin words are indexed in lower case, and CJK text by its characters
+// and bigrams of characters, so that Japanese keywords can be searched
+// without a dictionary. A keyword may also match a novel
+// containing its bigrams apart.
+type NovelIndex struct {
+	mu       sync.RWMutex
+	novels   map[int]*Novel
+	tokens   map[int][]string
+	postings map[string]map[int]int
+}
+
+// NovelHit is a novel found by NovelIndex.Search.
+type NovelHit struct {
+	Novel *Novel
+	// Score is the count of appearances of query tokens in the novel.
+	Score int
+}
+
+// NewNovelIndex returns an empty NovelIndex.
+func NewNovelIndex() *NovelIndex {
+	return &NovelIndex{
+		novels:   map[int]*Novel{},
+		tokens:   map[int][]string{},
+		postings: map[string]map[int]int{},
+	}
+}
+
+// Add indexes the title, caption, tags and text of novel,
+// replacing the previous one of the same ID.
+// text is usually RespNovelText.NovelText.
+func (idx *NovelIndex) Add(novel *Novel, text string) {
+	parts := []string{novel.Title, novel.Caption, text}
+	for _, t := range novel.Tags {
+		parts = append(parts, t.Name, t.TranslatedName)
+	}
+	counts := map[string]int{}
+	for _, tk := range tokenize(strings.Join(parts, "\n"), true) {
+		counts[tk]++
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(novel.ID)
+	idx.novels[novel.ID] = novel
+	tks := make([]string, 0, len(counts))
+	for tk, n := range counts {
+		p, ok := idx.postings[tk]
+		if !ok {
+			p = map[int]int{}
+			idx.postings[tk] = p
+		}
+		p[novel.ID] = n
+		tks = append(tks, tk)
+	}
+	idx.tokens[novel.ID] = tks
+}
+
+// Remove removes the novel from the index.
+func (idx *NovelIndex) Remove(novelID int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(novelID)
+}
+
+func (idx *NovelIndex) remove(novelID int) {
+	for _, tk := range idx.tokens[novelID] {
+		p := idx.postings[tk]
+		delete(p, novelID)
+		if len(p) == 0 {
+			delete(idx.postings, tk)
+		}
+	}
+	delete(idx.tokens, novelID)
+	delete(idx.novels, novelID)
+}
+
+// Len returns the count of novels in the index.
+func (idx *NovelIndex) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.novels)
+}
+
+// Search returns novels containing all keywords in query separated by spaces,
+// sorted by score in descending order.
+func (idx *NovelIndex) Search(query string) []NovelHit {
+	tks := tokenize(query, false)
+	if len(tks) == 0 {
+		return nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	scores := map[int]int{}
+	for id, n := range idx.postings[tks[0]] {
+		scores[id] = n
+	}
+	for _, tk := range tks[1:] {
+		p := idx.postings[tk]
+		for id := range scores {
+			n, ok := p[id]
+			if !ok {
+				delete(scores, id)
+				continue
+			}
+			scores[id] += n
+		}
+	}
+
+	hits := make([]NovelHit, 0, len(scores))
+	for id, s := range scores {
+		hits = append(hits, NovelHit{Novel: idx.novels[id], Score: s})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Novel.ID > hits[j].Novel.ID
+	})
+	return hits
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// tokenize splits s into lower-case words and CJK characters and bigrams.
+// If all is false, characters are only emitted for CJK runs of one character,
+// which is enough for searching.
+func tokenize(s string, all bool) []string {
+	var tks []string
+	var word []rune
+	var run []rune
+	flush := func() {
+		if len(word) > 0 {
+			tks = append(tks, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+		if len(run) == 1 || (all && len(run) > 0) {
+			for _, r := range run {
+				tks = append(tks, string(r))
+			}
+		}
+		for i := 0; i+1 < len(run); i++ {
+			tks = append(tks, string(run[i:i+2]))
+		}
+		run = run[:0]
+	}
+
+	for _, r := range FoldWidth(s) {
+		switch {
+		case isCJK(r):
+			if len(word) > 0 {
+				flush()
+			}
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(run) > 0 {
+				flush()
+			}
+			word = append(word, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tks
+}
@@ -0,0 +1,27 @@
+package pixiv
+
+import "testing"
+
+func TestNovelIndex(t *testing.T) {
+	idx := NewNovelIndex()
+	idx.Add(&Novel{ID: 1, Title: "夏の空"}, "青い空と白い雲。Summer sky.")
+	idx.Add(&Novel{ID: 2, Title: "冬"}, "雪が降る空。ｓｋｙ")
+	idx.Add(&Novel{ID: 3, Title: "Other"}, "nothing here")
+
+	h := idx.Search("空")
+	assert(len(h) == 2, h)
+
+	h = idx.Search("青い空")
+	assert(len(h) == 1 && h[0].Novel.ID == 1, h)
+
+	h = idx.Search("SKY")
+	assert(len(h) == 2, h)
+
+	h = idx.Search("sky 雪")
+	assert(len(h) == 1 && h[0].Novel.ID == 2, h)
+
+	idx.Remove(2)
+	assert(idx.Len() == 2, idx.Len())
+	h = idx.Search("雪")
+	assert(len(h) == 0, h)
+}
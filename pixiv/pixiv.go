@@ -4,7 +4,9 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -20,6 +22,10 @@ const (
 	authURL      = "https://oauth.secure.pixiv.net/auth/token"
 	timeOut      = 15 * time.Second
 	expiryDelta  = 30 * time.Second
+
+	maxResponseSize = 8 << 20
+	maxDownloadSize = 64 << 20
+	maxDrainSize    = 64 << 10
 )
 
 var baseHeader = http.Header{
@@ -54,9 +60,26 @@ type AppAPI struct {
 	TokenExpireAt    time.Time
 	TokenExpiryDelta time.Duration
 
+	// MaxResponseSize limits the size of response bodies from AppAPI and auth in bytes.
+	// Reading beyond it fails with ErrResponseTooLarge. 0 means no limit.
+	MaxResponseSize int64
+
+	// MaxDownloadSize limits the size of files downloaded from pximg in bytes,
+	// like the zip of ugoira. 0 means no limit.
+	MaxDownloadSize int64
+
 	// Contains details of login user.
 	AuthResponse *RespAuth
 
+	// OnDecodeError is called with the endpoint like "GET /v1/illust/detail",
+	// the raw body and the error when a response fails to be decoded,
+	// which helps collecting samples of schema changes of Pixiv.
+	// It is never called for auth responses, which contain credentials.
+	OnDecodeError func(endpoint string, body []byte, err error)
+
+	// SearchNormalizer normalizes words of illust, novel and tag searching if not nil.
+	SearchNormalizer *Normalizer
+
 	Client *http.Client // *http.Client with *Transport that can authorize requests automatically
 
 	service *service
@@ -85,6 +108,8 @@ func NewWithClient(client *http.Client) *AppAPI {
 		BaseHeader:       baseHeader.Clone(),
 		Client:           client,
 		TokenExpiryDelta: 600 * time.Second,
+		MaxResponseSize:  maxResponseSize,
+		MaxDownloadSize:  maxDownloadSize,
 	}
 
 	api.service = &service{api: api}
@@ -170,6 +195,26 @@ func (api *AppAPI) NewPximgRequest(method, url string, body io.Reader) (*http.Re
 	return req, nil
 }
 
+// download fetches the content of the pximg url.
+func (api *AppAPI) download(urls string) ([]byte, error) {
+	req, err := api.NewPximgRequest("GET", urls, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body := limitBody(resp.Body, api.MaxDownloadSize)
+	defer body.Close()
+
+	if resp.StatusCode >= 300 || resp.StatusCode < 200 {
+		return nil, fmt.Errorf("pixiv: GET %q %d", urls, resp.StatusCode)
+	}
+	return ioutil.ReadAll(body)
+}
+
 // receive sends the request and decode the response into successV or errorV.
 // If the status code is 2XX, the response will be decode into successV.
 // Otherwise, it will be decode into errorV.
@@ -178,26 +223,78 @@ func (api *AppAPI) receive(req *http.Request, successV interface{}, errorV inter
 	if err != nil {
 		return false, nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 300 && resp.StatusCode >= 200 {
-		if successV != nil {
-			dec := json.NewDecoder(resp.Body)
-			err = dec.Decode(successV)
-			if err != nil {
-				return false, nil, err
-			}
-		}
-		return true, resp, nil
+	body := limitBody(resp.Body, api.MaxResponseSize)
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return false, nil, err
 	}
-	if errorV != nil {
-		dec := json.NewDecoder(resp.Body)
-		err = dec.Decode(errorV)
+
+	ok := resp.StatusCode < 300 && resp.StatusCode >= 200
+	v := errorV
+	if ok {
+		v = successV
+	}
+	if v != nil {
+		err = api.decode(req, b, v)
 		if err != nil {
 			return false, nil, err
 		}
 	}
-	return false, resp, nil
+	return ok, resp, nil
+}
+
+// decode unmarshals b into v, and calls OnDecodeError if it fails.
+func (api *AppAPI) decode(req *http.Request, b []byte, v interface{}) error {
+	err := json.Unmarshal(b, v)
+	if err != nil && api.OnDecodeError != nil {
+		api.OnDecodeError(req.Method+" "+req.URL.Path, b, err)
+	}
+	return err
+}
+
+// limitedBody returns ErrResponseTooLarge after n bytes are read if n > 0.
+type limitedBody struct {
+	body io.ReadCloser
+	n    int64
+}
+
+// limitBody wraps body with the size limit n.
+// Closing it drains up to 64 KB of the rest of body within the limit,
+// so that the connection can be reused.
+// Like net/http, a larger rest of body closes the connection instead.
+func limitBody(body io.ReadCloser, n int64) io.ReadCloser {
+	if n <= 0 {
+		n = -1
+	}
+	return &limitedBody{body: body, n: n}
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n == -1 {
+		return l.body.Read(p)
+	}
+	if l.n == 0 {
+		// Read one more byte to tell the end of body from an oversized body.
+		var b [1]byte
+		n, err := l.body.Read(b[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.body.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	io.CopyN(ioutil.Discard, l, maxDrainSize)
+	return l.body.Close()
 }
 
 func (api *AppAPI) withAppAPIErrors(req *http.Request, v interface{}) (*http.Response, error) {
@@ -237,6 +334,17 @@ func (api *AppAPI) post(r interface{}, urls string, data url.Values) error {
 	return err
 }
 
+func (api *AppAPI) postMultipart(r interface{}, urls string, body io.Reader, contentType string) error {
+	req, err := api.NewAuthorizedRequest("POST", urls, body)
+	if err != nil {
+		return err
+	}
+	req.Header["Content-Type"] = []string{contentType}
+
+	_, err = api.withAppAPIErrors(req, r)
+	return err
+}
+
 func (api *AppAPI) getWithValues(r interface{}, urls string, opts interface{}, values url.Values, caller string) error {
 	q, err := withOpts(opts, values, caller)
 	if err != nil {
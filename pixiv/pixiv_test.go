@@ -1,8 +1,11 @@
 package pixiv
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
@@ -48,3 +51,92 @@ func getTestAPI(t *testing.T) *AppAPI {
 	testAPI = api
 	return api
 }
+
+type closeRecorder struct {
+	*strings.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestLimitBodyClose(t *testing.T) {
+	// Drained after a partial read
+	cr := &closeRecorder{Reader: strings.NewReader("123456")}
+	l := limitBody(cr, 10)
+	l.Read(make([]byte, 2))
+	l.Close()
+	assert(cr.closed && cr.Len() == 0, cr.closed, cr.Len())
+
+	// Drained after a decode error
+	cr = &closeRecorder{Reader: strings.NewReader(`{"illust": 1} trailing garbage`)}
+	l = limitBody(cr, 100)
+	err := json.NewDecoder(l).Decode(&RespIllust{})
+	assert(err != nil)
+	l.Close()
+	assert(cr.closed && cr.Len() == 0, cr.closed, cr.Len())
+
+	// Closed without reading beyond the limit
+	cr = &closeRecorder{Reader: strings.NewReader("1234567890")}
+	l = limitBody(cr, 4)
+	l.Close()
+	assert(cr.closed && cr.Len() == 5, cr.closed, cr.Len())
+
+	// Drained up to maxDrainSize without the limit
+	cr = &closeRecorder{Reader: strings.NewReader(strings.Repeat("1", maxDrainSize+10))}
+	l = limitBody(cr, 0)
+	l.Close()
+	assert(cr.closed && cr.Len() == 10, cr.closed, cr.Len())
+
+	// Drained up to maxDrainSize within a large limit
+	cr = &closeRecorder{Reader: strings.NewReader(strings.Repeat("1", 2*maxDrainSize))}
+	l = limitBody(cr, maxDownloadSize)
+	l.Read(make([]byte, 10))
+	l.Close()
+	assert(cr.closed && cr.Len() == maxDrainSize-10, cr.closed, cr.Len())
+}
+
+func TestLimitBody(t *testing.T) {
+	b, err := ioutil.ReadAll(limitBody(ioutil.NopCloser(strings.NewReader("1234")), 4))
+	assert(err == nil && string(b) == "1234", err, b)
+
+	_, err = ioutil.ReadAll(limitBody(ioutil.NopCloser(strings.NewReader("12345")), 4))
+	assert(err == ErrResponseTooLarge, err)
+
+	b, err = ioutil.ReadAll(limitBody(ioutil.NopCloser(strings.NewReader("12345")), 0))
+	assert(err == nil && string(b) == "12345", err, b)
+}
+
+func TestOnDecodeError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/token":
+			w.Write([]byte(`{"response":{"access_token":"secret","refresh_token":"secret","expires_in":"x",` +
+				`"user":{"mail_address":"a@example.com"}}}`))
+		case "/v1/illust/detail":
+			w.Write([]byte(`{"illust":{"id":"1"}}`))
+		}
+	}))
+	defer ts.Close()
+
+	api := New()
+	api.BaseURL = ts.URL
+	api.AuthURL = ts.URL + "/auth/token"
+	var endpoints []string
+	api.OnDecodeError = func(ep string, body []byte, err error) {
+		endpoints = append(endpoints, ep)
+	}
+
+	// Auth responses are not passed to the hook.
+	api.SetRefreshToken("token")
+	_, err := api.ForceAuth()
+	assert(err != nil)
+	assert(len(endpoints) == 0, endpoints)
+
+	api.AccessToken = "token"
+	_, err = api.Illust.Detail(1)
+	assert(err != nil)
+	assert(len(endpoints) == 1 && endpoints[0] == "GET /v1/illust/detail", endpoints)
+}
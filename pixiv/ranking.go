@@ -0,0 +1,140 @@
+package pixiv
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RankingCheckpoint is the position of RankingRange to resume from.
+type RankingCheckpoint struct {
+	Date   Date `json:"date"`
+	Offset int  `json:"offset"`
+}
+
+// RankingPage is a page of ranking illusts sent by RankingRange.
+type RankingPage struct {
+	Date    Date
+	Offset  int
+	Illusts []*Illust
+
+	// Next is the position of the page after this one.
+	// Save it after the page is processed, and pass it to
+	// RankingRangeOptions.Resume to continue from there.
+	Next RankingCheckpoint
+
+	// Err is set if fetching the page failed, and it is the last page sent.
+	Err error
+}
+
+// RankingRangeOptions defines options of RankingRange.
+type RankingRangeOptions struct {
+	Filter string
+
+	// Resume starts from the checkpoint instead of the from date,
+	// usually RankingPage.Next of the last page processed.
+	Resume *RankingCheckpoint
+
+	// Interval is the time waited between requests. Defaults to 1 second.
+	Interval time.Duration
+
+	// Done stops the iteration when closed.
+	Done <-chan struct{}
+}
+
+// RankingRange fetches all pages of ranking illusts of mode for every day
+// from from to to inclusive, and sends them to the returned channel,
+// which is closed after the last page.
+// The caller must read the channel until it is closed, or close opts.Done
+// when stopping early. Otherwise the fetching goroutine blocks forever.
+func (s *IllustService) RankingRange(mode RankingMode, from, to time.Time, opts *RankingRangeOptions) <-chan *RankingPage {
+	o := RankingRangeOptions{Interval: time.Second}
+	if opts != nil {
+		o = *opts
+		if o.Interval == 0 {
+			o.Interval = time.Second
+		}
+	}
+
+	ch := make(chan *RankingPage)
+	go func() {
+		defer close(ch)
+		send := func(p *RankingPage) bool {
+			select {
+			case ch <- p:
+				return true
+			case <-o.Done:
+				return false
+			}
+		}
+
+		day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+		end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+		offset := 0
+		if o.Resume != nil {
+			t, err := o.Resume.Date.Time()
+			if err != nil {
+				send(&RankingPage{Date: o.Resume.Date, Err: fmt.Errorf("pixiv: ranking range: resume: %w", err)})
+				return
+			}
+			day, offset = t, o.Resume.Offset
+		}
+
+		first := true
+		for !day.After(end) {
+			if !first {
+				select {
+				case <-time.After(o.Interval):
+				case <-o.Done:
+					return
+				}
+			}
+			first = false
+
+			date := NewDate(day.Year(), int(day.Month()), day.Day())
+			r, err := s.Ranking(&RankingQuery{
+				Filter: o.Filter,
+				Mode:   mode,
+				Date:   string(date),
+				Offset: offset,
+			})
+			if err != nil {
+				send(&RankingPage{Date: date, Offset: offset, Err: err})
+				return
+			}
+			next, err := nextOffset(r.NextURL)
+			if err != nil {
+				// Moving on to the next day would lose the rest of this day.
+				send(&RankingPage{Date: date, Offset: offset, Err: err})
+				return
+			}
+			p := &RankingPage{Date: date, Offset: offset, Illusts: r.Illusts}
+			offset = next
+			if offset == 0 {
+				day = day.AddDate(0, 0, 1)
+			}
+			p.Next = RankingCheckpoint{Date: NewDate(day.Year(), int(day.Month()), day.Day()), Offset: offset}
+			if !send(p) {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// nextOffset returns the offset query in nextURL, or 0 if nextURL is empty.
+func nextOffset(nextURL string) (int, error) {
+	if nextURL == "" {
+		return 0, nil
+	}
+	u, err := url.Parse(nextURL)
+	if err != nil {
+		return 0, fmt.Errorf("pixiv: ranking range: next_url: %w", err)
+	}
+	n, err := strconv.Atoi(u.Query().Get("offset"))
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("pixiv: ranking range: no offset in next_url %q", nextURL)
+	}
+	return n, nil
+}
@@ -0,0 +1,68 @@
+package pixiv
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextOffset(t *testing.T) {
+	n, err := nextOffset("https://app-api.pixiv.net/v1/illust/ranking?mode=day&date=2020-04-03&offset=30")
+	assert(err == nil && n == 30, n, err)
+
+	n, err = nextOffset("")
+	assert(err == nil && n == 0, n, err)
+
+	_, err = nextOffset("https://app-api.pixiv.net/v1/illust/ranking?mode=day")
+	assert(err != nil)
+
+	_, err = nextOffset("://bad")
+	assert(err != nil)
+}
+
+func TestRankingRange(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// Two pages a day, and the ID tells the date and offset.
+		id := strings.Replace(q.Get("date"), "-", "", -1) + q.Get("offset")
+		next := ""
+		if q.Get("offset") == "" {
+			next = `https://app-api.pixiv.net/v1/illust/ranking?offset=30`
+		}
+		w.Write([]byte(`{"illusts":[{"id":` + id + `}],"next_url":"` + next + `"}`))
+	}))
+	defer ts.Close()
+
+	api := New()
+	api.BaseURL = ts.URL
+	api.AccessToken = "token"
+	from := time.Date(2020, 4, 3, 12, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	opts := &RankingRangeOptions{Interval: time.Millisecond}
+
+	var pages []*RankingPage
+	for p := range api.Illust.RankingRange(RMDay, from, to, opts) {
+		if p.Err != nil {
+			t.Fatal(p.Err)
+		}
+		pages = append(pages, p)
+	}
+	assert(len(pages) == 4, len(pages))
+	assert(pages[0].Illusts[0].ID == 20200403, pages[0].Illusts[0].ID)
+	assert(pages[0].Next == RankingCheckpoint{Date: "2020-04-03", Offset: 30}, pages[0].Next)
+	assert(pages[1].Next == RankingCheckpoint{Date: "2020-04-04"}, pages[1].Next)
+	assert(pages[3].Next == RankingCheckpoint{Date: "2020-04-05"}, pages[3].Next)
+
+	// Resuming from the checkpoint of a page continues after it.
+	opts.Resume = &pages[2].Next
+	var resumed []*RankingPage
+	for p := range api.Illust.RankingRange(RMDay, from, to, opts) {
+		if p.Err != nil {
+			t.Fatal(p.Err)
+		}
+		resumed = append(resumed, p)
+	}
+	assert(len(resumed) == 1 && resumed[0].Illusts[0].ID == 2020040430, resumed)
+}
@@ -0,0 +1,133 @@
+package pixiv
+
+import (
+	"sync"
+	"time"
+)
+
+// Relationships checks follow relationships between the login user and others.
+// Followings and followers of the login user are fetched on first use,
+// and cached for TTL.
+type Relationships struct {
+	api *AppAPI
+
+	// TTL is how long the cached lists are used before fetching again.
+	// 0 means caching forever until Invalidate is called.
+	TTL time.Duration
+
+	// Interval is the time waited between requests of pages. Defaults to 1 second.
+	Interval time.Duration
+
+	// Followings and followers are filled separately,
+	// so that checking one does not wait for filling the other.
+	followingMu sync.Mutex
+	followersMu sync.Mutex
+	following   map[int]struct{}
+	followers   map[int]struct{}
+	followingAt time.Time
+	followersAt time.Time
+}
+
+// NewRelationships returns Relationships of the login user of api.
+func NewRelationships(api *AppAPI, ttl time.Duration) *Relationships {
+	return &Relationships{api: api, TTL: ttl, Interval: time.Second}
+}
+
+// IsFollowing checks if the login user follows the user publicly or privately.
+func (r *Relationships) IsFollowing(userID int) (bool, error) {
+	r.followingMu.Lock()
+	defer r.followingMu.Unlock()
+
+	if r.expired(r.following, r.followingAt) {
+		ids := map[int]struct{}{}
+		for i, restrict := range []Restrict{RPublic, RPrivate} {
+			if i > 0 {
+				time.Sleep(r.interval())
+			}
+			err := r.collect(ids, func(me int) (*RespUserPreviews, error) {
+				return r.api.User.Followings(me, &FollowingQuery{Restrict: restrict})
+			})
+			if err != nil {
+				return false, err
+			}
+		}
+		r.following, r.followingAt = ids, time.Now()
+	}
+	_, ok := r.following[userID]
+	return ok, nil
+}
+
+// IsFollowedBy checks if the user follows the login user.
+func (r *Relationships) IsFollowedBy(userID int) (bool, error) {
+	r.followersMu.Lock()
+	defer r.followersMu.Unlock()
+
+	if r.expired(r.followers, r.followersAt) {
+		ids := map[int]struct{}{}
+		err := r.collect(ids, func(me int) (*RespUserPreviews, error) {
+			return r.api.User.Followers(me, nil)
+		})
+		if err != nil {
+			return false, err
+		}
+		r.followers, r.followersAt = ids, time.Now()
+	}
+	_, ok := r.followers[userID]
+	return ok, nil
+}
+
+// IsMutualFollow checks if the login user and the user follow each other.
+func (r *Relationships) IsMutualFollow(userID int) (bool, error) {
+	ok, err := r.IsFollowing(userID)
+	if err != nil || !ok {
+		return false, err
+	}
+	return r.IsFollowedBy(userID)
+}
+
+// Invalidate clears the cached lists.
+func (r *Relationships) Invalidate() {
+	r.followingMu.Lock()
+	r.following = nil
+	r.followingMu.Unlock()
+
+	r.followersMu.Lock()
+	r.followers = nil
+	r.followersMu.Unlock()
+}
+
+func (r *Relationships) interval() time.Duration {
+	if r.Interval == 0 {
+		return time.Second
+	}
+	return r.Interval
+}
+
+func (r *Relationships) expired(ids map[int]struct{}, at time.Time) bool {
+	return ids == nil || (r.TTL > 0 && time.Since(at) > r.TTL)
+}
+
+// collect adds IDs of all user previews from first and its next pages into ids.
+func (r *Relationships) collect(ids map[int]struct{}, first func(me int) (*RespUserPreviews, error)) error {
+	if r.api.UserID == 0 {
+		_, err := r.api.ForceAuth()
+		if err != nil {
+			return err
+		}
+	}
+
+	rp, err := first(r.api.UserID)
+	for {
+		if err != nil {
+			return err
+		}
+		for _, p := range rp.UserPreviews {
+			ids[p.User.ID] = struct{}{}
+		}
+		if rp.NextURL == "" {
+			return nil
+		}
+		time.Sleep(r.interval())
+		rp, err = rp.NextFollowing()
+	}
+}
@@ -0,0 +1,83 @@
+package pixiv
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRelationshipsCache(t *testing.T) {
+	var requests int32
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/v1/user/following" && q.Get("restrict") == "public" && q.Get("offset") == "":
+			w.Write([]byte(`{"user_previews":[{"user":{"id":2}}],` +
+				`"next_url":"` + ts.URL + `/v1/user/following?restrict=public&offset=30"}`))
+		case r.URL.Path == "/v1/user/following" && q.Get("restrict") == "public":
+			w.Write([]byte(`{"user_previews":[{"user":{"id":3}}]}`))
+		case r.URL.Path == "/v1/user/following":
+			w.Write([]byte(`{"user_previews":[{"user":{"id":4}}]}`))
+		case r.URL.Path == "/v1/user/follower":
+			w.Write([]byte(`{"user_previews":[{"user":{"id":2}},{"user":{"id":5}}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	api := New()
+	api.BaseURL = ts.URL
+	api.AccessToken = "token"
+	api.UserID = 1
+	rel := NewRelationships(api, 50*time.Millisecond)
+	rel.Interval = time.Millisecond
+
+	for _, id := range []int{2, 3, 4} {
+		ok, err := rel.IsFollowing(id)
+		assert(err == nil && ok, id, err)
+	}
+	ok, _ := rel.IsFollowing(5)
+	assert(!ok, 5)
+	// Public followings take 2 pages, and private ones take 1.
+	assert(atomic.LoadInt32(&requests) == 3, requests)
+
+	ok, _ = rel.IsMutualFollow(2)
+	assert(ok, 2)
+	ok, _ = rel.IsMutualFollow(3)
+	assert(!ok, 3)
+	ok, _ = rel.IsFollowedBy(5)
+	assert(ok, 5)
+	assert(atomic.LoadInt32(&requests) == 4, requests)
+
+	// Refetched after TTL.
+	time.Sleep(60 * time.Millisecond)
+	rel.IsFollowedBy(5)
+	assert(atomic.LoadInt32(&requests) == 5, requests)
+
+	// Refetched after Invalidate.
+	rel.Invalidate()
+	rel.IsFollowing(2)
+	assert(atomic.LoadInt32(&requests) == 8, requests)
+}
+
+func TestRelationships(t *testing.T) {
+	id := 23459386
+	api := getTestAPI(t)
+	r := NewRelationships(api, time.Minute)
+
+	_, err := r.IsFollowing(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.IsFollowedBy(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.IsMutualFollow(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -8,7 +8,8 @@ import (
 
 //
 var (
-	ErrEmptyNextURL = errors.New("pixiv: empty next_url field")
+	ErrEmptyNextURL     = errors.New("pixiv: empty next_url field")
+	ErrResponseTooLarge = errors.New("pixiv: response body too large")
 )
 
 // Generated by https://quicktype.io
@@ -85,6 +86,9 @@ type RespComments struct {
 	Comments []*Comment `json:"comments"`
 	NextURL  string     `json:"next_url"`
 
+	// TotalComments is only returned by illust and novel comments, not replies.
+	TotalComments int `json:"total_comments"`
+
 	api *AppAPI
 }
 
@@ -206,6 +210,9 @@ type RespUserDetail struct {
 // RespUserPreviews is the response from:
 //
 //  /v1/user/following?restrict=...&user_id=...
+//  /v1/user/follower?user_id=...
+//  /v1/user/recommended
+//  /v1/search/user?word=...
 type RespUserPreviews struct {
 	UserPreviews []*UserPreview `json:"user_previews"`
 	NextURL      string         `json:"next_url"`
@@ -298,3 +305,39 @@ type RespNovelSeries struct {
 	Novels                 []*Novel          `json:"novels"`
 	NextURL                string            `json:"next_url"`
 }
+
+// RespUpload is the response from:
+//
+//  POST /v1/upload/work
+type RespUpload struct {
+	ConvertKey string `json:"convert_key"`
+}
+
+// RespUploadStatus is the response from:
+//
+//  GET /v1/upload/status?convert_key=...
+type RespUploadStatus struct {
+	Status struct {
+		// "COMPLETE" when the work is posted.
+		Status   string `json:"status"`
+		IllustID int    `json:"illust_id"`
+	} `json:"status"`
+}
+
+// RespBookmarkDetail is the response from:
+//
+//  /v2/illust/bookmark/detail?illust_id=...
+//  /v2/novel/bookmark/detail?novel_id=...
+type RespBookmarkDetail struct {
+	BookmarkDetail struct {
+		IsBookmarked bool     `json:"is_bookmarked"`
+		Restrict     Restrict `json:"restrict"`
+
+		// Tags contains all bookmark tags of the login user,
+		// with IsRegistered set on the ones of this work.
+		Tags []struct {
+			Name         string `json:"name"`
+			IsRegistered bool   `json:"is_registered"`
+		} `json:"tags"`
+	} `json:"bookmark_detail"`
+}
@@ -83,7 +83,7 @@ func (s *SearchService) illusts(urls, word string, opts *SearchQuery, caller str
 	r := &RespIllusts{api: s.api}
 	err := s.api.getWithValues(r,
 		s.api.BaseURL+urls, opts, url.Values{
-			"word":                           {word},
+			"word":                           {s.api.SearchNormalizer.Normalize(word)},
 			"include_translated_tag_results": {"true"},
 			"merge_plain_keyword_results":    {"true"},
 		}, "search: "+caller,
@@ -111,7 +111,7 @@ func (s *SearchService) novels(ep, word string, opts *SearchQuery, caller string
 	r := &RespNovels{api: s.api}
 	err := s.api.getWithValues(r,
 		s.api.BaseURL+ep, opts, url.Values{
-			"word":                           {word},
+			"word":                           {s.api.SearchNormalizer.Normalize(word)},
 			"include_translated_tag_results": {"true"},
 			"merge_plain_keyword_results":    {"true"},
 		}, "search: "+caller,
@@ -139,7 +139,7 @@ func (s *SearchService) TagsStartWith(word string) (*RespTags, error) {
 	r := &RespTags{}
 	err := s.api.getWithValues(r,
 		s.api.BaseURL+"/v2/search/autocomplete", nil, url.Values{
-			"word":                        {word},
+			"word":                        {s.api.SearchNormalizer.Normalize(word)},
 			"merge_plain_keyword_results": {"true"},
 		}, "search: tag autocomplete",
 	)
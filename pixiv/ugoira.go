@@ -0,0 +1,136 @@
+package pixiv
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	// Frames of ugoira are in JPEG or PNG.
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// UgoiraPreviewOptions defines how frames are sampled in UgoiraPreview.
+type UgoiraPreviewOptions struct {
+	// MaxFrames limits the number of frames in the preview. Defaults to 10.
+	MaxFrames int
+
+	// Step takes every Step-th frame of the ugoira. Defaults to 1.
+	// Delays of skipped frames are added to the taken ones to keep the speed.
+	Step int
+
+	// MaxWidth scales frames down to fit the width. 0 keeps the original size.
+	MaxWidth int
+}
+
+// UgoiraPreview writes a looping GIF preview of the ugoira into w.
+// It downloads the medium zip, and only decodes the sampled frames.
+func (s *IllustService) UgoiraPreview(illustID int, w io.Writer, opts *UgoiraPreviewOptions) error {
+	m, err := s.UgoiraMetadata(illustID)
+	if err != nil {
+		return err
+	}
+	b, err := s.api.download(m.UgoiraMetadata.ZipURLs.Medium)
+	if err != nil {
+		return err
+	}
+	return WriteUgoiraPreview(w, bytes.NewReader(b), int64(len(b)), m, opts)
+}
+
+// WriteUgoiraPreview encodes sampled frames of the ugoira zip r of the given size
+// with metadata m into a looping GIF and writes it into w.
+func WriteUgoiraPreview(w io.Writer, r io.ReaderAt, size int64, m *RespUgoiraMetadata, opts *UgoiraPreviewOptions) error {
+	o := UgoiraPreviewOptions{MaxFrames: 10, Step: 1}
+	if opts != nil {
+		if opts.MaxFrames > 0 {
+			o.MaxFrames = opts.MaxFrames
+		}
+		if opts.Step > 0 {
+			o.Step = opts.Step
+		}
+		o.MaxWidth = opts.MaxWidth
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("pixiv: ugoira preview: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	frames := m.UgoiraMetadata.Frames
+	g := &gif.GIF{}
+	for i := 0; i < len(frames) && len(g.Image) < o.MaxFrames; i += o.Step {
+		f, ok := files[frames[i].File]
+		if !ok {
+			return fmt.Errorf("pixiv: ugoira preview: frame %q not found in zip", frames[i].File)
+		}
+		img, err := decodeZipImage(f)
+		if err != nil {
+			return fmt.Errorf("pixiv: ugoira preview: frame %q: %w", frames[i].File, err)
+		}
+
+		delay := 0
+		for j := i; j < i+o.Step && j < len(frames); j++ {
+			delay += frames[j].Delay
+		}
+		g.Image = append(g.Image, toPaletted(img, o.MaxWidth))
+		// Delays of GIF are in 100ths of a second.
+		g.Delay = append(g.Delay, delay/10)
+	}
+	if len(g.Image) == 0 {
+		return errors.New("pixiv: ugoira preview: no frames")
+	}
+
+	err = gif.EncodeAll(w, g)
+	if err != nil {
+		return fmt.Errorf("pixiv: ugoira preview: gif encode: %w", err)
+	}
+	return nil
+}
+
+func decodeZipImage(f *zip.File) (image.Image, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	img, _, err := image.Decode(rc)
+	return img, err
+}
+
+// toPaletted scales img down to maxWidth with nearest-neighbor sampling
+// and converts it into a paletted image.
+func toPaletted(img image.Image, maxWidth int) *image.Paletted {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if maxWidth > 0 && w > maxWidth {
+		h = h * maxWidth / w
+		if h == 0 {
+			h = 1
+		}
+		w = maxWidth
+
+		scaled := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			sy := b.Min.Y + y*b.Dy()/h
+			for x := 0; x < w; x++ {
+				scaled.Set(x, y, img.At(b.Min.X+x*b.Dx()/w, sy))
+			}
+		}
+		img = scaled
+		b = scaled.Bounds()
+	}
+
+	p := image.NewPaletted(image.Rect(0, 0, w, h), palette.Plan9)
+	draw.FloydSteinberg.Draw(p, p.Bounds(), img, b.Min)
+	return p
+}
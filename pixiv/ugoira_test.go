@@ -0,0 +1,59 @@
+package pixiv
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+func TestWriteUgoiraPreview(t *testing.T) {
+	m := &RespUgoiraMetadata{}
+	zb := &bytes.Buffer{}
+	zw := zip.NewWriter(zb)
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("%06d.png", i)
+		m.UgoiraMetadata.Frames = append(m.UgoiraMetadata.Frames, struct {
+			File  string `json:"file"`
+			Delay int    `json:"delay"`
+		}{name, 100})
+
+		img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+		img.Set(i, 0, color.White)
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = png.Encode(f, img)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	err = WriteUgoiraPreview(out, bytes.NewReader(zb.Bytes()), int64(zb.Len()), m, &UgoiraPreviewOptions{
+		MaxFrames: 2,
+		Step:      2,
+		MaxWidth:  20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := gif.DecodeAll(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(len(g.Image) == 2, len(g.Image))
+	assert(g.Delay[0] == 20, g.Delay)
+	b := g.Image[0].Bounds()
+	assert(b.Dx() == 20 && b.Dy() == 10, b)
+}
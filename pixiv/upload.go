@@ -0,0 +1,167 @@
+package pixiv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"time"
+)
+
+const uploadPollTimes = 60
+
+var uploadPollInterval = 2 * time.Second
+
+// IllustUploadParams defines form fields in Upload.
+type IllustUploadParams struct {
+	Title   string   `url:"title"`
+	Caption string   `url:"caption,omitempty"`
+	Tags    []string `url:"tags[],omitempty"`
+
+	// TIllust or TManga. Defaults to TIllust.
+	Type Type `url:"type,omitempty"`
+
+	// RPublic or RPrivate, or "mypixiv". Defaults to RPublic.
+	Restrict Restrict `url:"restrict,omitempty"`
+
+	// "general", "r18" or "r18g". Defaults to "general".
+	XRestrict string `url:"x_restrict,omitempty"`
+
+	// AIGenerated marks the work as generated by AI.
+	AIGenerated bool `url:"-"`
+}
+
+// UploadOptions defines options of UploadWithOpts.
+type UploadOptions struct {
+	// Done stops waiting for the conversion when closed.
+	// The work may still be posted by Pixiv after that.
+	Done <-chan struct{}
+}
+
+// Upload posts a new work with images in the order of pages,
+// waits until Pixiv finishes converting it, and returns the ID of the work.
+// It fails if the conversion ends with a status other than "COMPLETE".
+// Images must be in JPEG, PNG or GIF.
+func (s *IllustService) Upload(params *IllustUploadParams, images ...io.Reader) (int, error) {
+	return s.UploadWithOpts(params, nil, images...)
+}
+
+// UploadWithOpts is Upload with options.
+func (s *IllustService) UploadWithOpts(params *IllustUploadParams, opts *UploadOptions, images ...io.Reader) (int, error) {
+	if len(images) == 0 {
+		return 0, errors.New("pixiv: illust: upload: no images")
+	}
+	body, contentType, err := newUploadBody(params, images)
+	if err != nil {
+		return 0, err
+	}
+
+	r := &RespUpload{}
+	err = s.api.postMultipart(r, s.api.BaseURL+"/v1/upload/work", body, contentType)
+	if err != nil {
+		return 0, err
+	}
+
+	var done <-chan struct{}
+	if opts != nil {
+		done = opts.Done
+	}
+	for i := 0; i < uploadPollTimes; i++ {
+		select {
+		case <-time.After(uploadPollInterval):
+		case <-done:
+			return 0, fmt.Errorf("pixiv: illust: upload: canceled waiting convert_key %q", r.ConvertKey)
+		}
+		rs := &RespUploadStatus{}
+		err := s.api.getWithValues(rs,
+			s.api.BaseURL+"/v1/upload/status", nil, url.Values{
+				"convert_key": {r.ConvertKey},
+			}, "illust: upload status",
+		)
+		if err != nil {
+			return 0, err
+		}
+		switch rs.Status.Status {
+		case "COMPLETE":
+			return rs.Status.IllustID, nil
+		case "", "WAITING", "PROCESSING", "CONVERTING":
+		default:
+			return 0, fmt.Errorf("pixiv: illust: upload: convert_key %q: status %s", r.ConvertKey, rs.Status.Status)
+		}
+	}
+	return 0, fmt.Errorf("pixiv: illust: upload: timeout waiting convert_key %q", r.ConvertKey)
+}
+
+func newUploadBody(params *IllustUploadParams, images []io.Reader) (*bytes.Buffer, string, error) {
+	if params == nil {
+		params = &IllustUploadParams{}
+	}
+	values := url.Values{
+		"type":           {string(TIllust)},
+		"restrict":       {string(RPublic)},
+		"x_restrict":     {"general"},
+		"illust_ai_type": {"1"},
+	}
+	if params.AIGenerated {
+		values.Set("illust_ai_type", "2")
+	}
+	// Defaults are overwritten by params.
+	v, err := withOpts(params, nil, "illust: upload")
+	if err != nil {
+		return nil, "", err
+	}
+	for k, vs := range v {
+		values[k] = vs
+	}
+
+	b := &bytes.Buffer{}
+	mw := multipart.NewWriter(b)
+	for k, vs := range values {
+		for _, x := range vs {
+			err := mw.WriteField(k, x)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	for i, img := range images {
+		data, err := ioutil.ReadAll(img)
+		if err != nil {
+			return nil, "", fmt.Errorf("pixiv: illust: upload: image %d: %w", i, err)
+		}
+		ct := http.DetectContentType(data)
+		var ext string
+		switch ct {
+		case "image/jpeg":
+			ext = ".jpg"
+		case "image/png":
+			ext = ".png"
+		case "image/gif":
+			ext = ".gif"
+		default:
+			return nil, "", fmt.Errorf("pixiv: illust: upload: image %d: unsupported content type %q", i, ct)
+		}
+
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[]"; filename="%d%s"`, i, ext))
+		h.Set("Content-Type", ct)
+		w, err := mw.CreatePart(h)
+		if err != nil {
+			return nil, "", err
+		}
+		_, err = w.Write(data)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	err = mw.Close()
+	if err != nil {
+		return nil, "", err
+	}
+	return b, mw.FormDataContentType(), nil
+}
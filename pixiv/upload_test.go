@@ -0,0 +1,102 @@
+package pixiv
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewUploadBody(t *testing.T) {
+	img := &bytes.Buffer{}
+	err := png.Encode(img, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, ct, err := newUploadBody(&IllustUploadParams{
+		Title:       "test",
+		Tags:        []string{"a", "b"},
+		Restrict:    RPrivate,
+		AIGenerated: true,
+	}, []io.Reader{img})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, mp, err := mime.ParseMediaType(ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := multipart.NewReader(b, mp["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(f.Value["title"][0] == "test", f.Value)
+	assert(len(f.Value["tags[]"]) == 2, f.Value)
+	assert(f.Value["restrict"][0] == "private", f.Value)
+	assert(f.Value["type"][0] == "illust", f.Value)
+	assert(f.Value["illust_ai_type"][0] == "2", f.Value)
+
+	fh := f.File["files[]"]
+	assert(len(fh) == 1 && fh[0].Filename == "0.png", fh)
+	r, err := fh[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := ioutil.ReadAll(r)
+	assert(len(data) > 0)
+}
+
+func TestUploadStatus(t *testing.T) {
+	status := "FAILED"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/upload/work":
+			w.Write([]byte(`{"convert_key":"key"}`))
+		case "/v1/upload/status":
+			w.Write([]byte(`{"status":{"status":"` + status + `","illust_id":1}}`))
+		}
+	}))
+	defer ts.Close()
+
+	interval := uploadPollInterval
+	uploadPollInterval = time.Millisecond
+	defer func() { uploadPollInterval = interval }()
+
+	api := New()
+	api.BaseURL = ts.URL
+	api.AccessToken = "token"
+	pb := &bytes.Buffer{}
+	err := png.Encode(pb, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := func() io.Reader { return bytes.NewReader(pb.Bytes()) }
+
+	// Non-image input is rejected before posting.
+	_, err = api.Illust.Upload(&IllustUploadParams{Title: "test"}, bytes.NewReader([]byte("text")))
+	assert(err != nil && strings.Contains(err.Error(), "content type"), err)
+
+	_, err = api.Illust.Upload(&IllustUploadParams{Title: "test"}, img())
+	assert(err != nil && strings.Contains(err.Error(), "FAILED"), err)
+
+	status = "COMPLETE"
+	id, err := api.Illust.Upload(&IllustUploadParams{Title: "test"}, img())
+	assert(err == nil && id == 1, id, err)
+
+	status = "PROCESSING"
+	done := make(chan struct{})
+	close(done)
+	uploadPollInterval = time.Hour
+	_, err = api.Illust.UploadWithOpts(&IllustUploadParams{Title: "test"}, &UploadOptions{Done: done}, img())
+	assert(err != nil && strings.Contains(err.Error(), "canceled"), err)
+}
@@ -39,6 +39,12 @@ type FollowingQuery struct {
 	Offset   int      `url:"offset,omitempty"`
 }
 
+// FollowerQuery defines url query struct in fetching user's followers.
+type FollowerQuery struct {
+	Filter string `url:"filter,omitempty"` //for_ios
+	Offset int    `url:"offset,omitempty"`
+}
+
 // Detail fetches user profile from /v1/user/detail
 func (s *UserService) Detail(userID int, opts *UserDetailQuery) (*RespUserDetail, error) {
 	r := &RespUserDetail{}
@@ -125,6 +131,21 @@ func (s *UserService) Followings(userID int, opts *FollowingQuery) (*RespUserPre
 	return r, nil
 }
 
+// Followers fetches user's followers.
+// Pixiv only returns followers of the login user.
+func (s *UserService) Followers(userID int, opts *FollowerQuery) (*RespUserPreviews, error) {
+	r := &RespUserPreviews{api: s.api}
+	err := s.api.getWithValues(r,
+		s.api.BaseURL+"/v1/user/follower", opts, url.Values{
+			"user_id": {strconv.Itoa(userID)},
+		}, "user's followers",
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // Recommended fetches recommend users.
 func (s *UserService) Recommended(opts *RecommendedUsersQuery) (*RespUserPreviews, error) {
 	r := &RespUserPreviews{api: s.api}
@@ -57,4 +57,8 @@ func TestUser(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	_, err = api.User.Followers(api.UserID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 }
@@ -0,0 +1,284 @@
+package pixiv
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SeenStore stores IDs of works seen by Watcher.
+// Implementations must be safe for concurrent use,
+// so that multiple watchers can share one store.
+type SeenStore interface {
+	// Seen checks if id has been marked as seen.
+	Seen(id int) (bool, error)
+	// MarkSeen marks ids as seen, and returns the ones not seen before.
+	// Checking and marking must be atomic, so that an id is returned by
+	// only one of the concurrent calls marking it.
+	MarkSeen(ids ...int) ([]int, error)
+}
+
+// MemorySeenStore keeps seen IDs in memory.
+type MemorySeenStore struct {
+	mu  sync.RWMutex
+	ids map[int]struct{}
+}
+
+// NewMemorySeenStore returns an empty MemorySeenStore.
+func NewMemorySeenStore() *MemorySeenStore {
+	return &MemorySeenStore{ids: map[int]struct{}{}}
+}
+
+// Seen checks if id has been marked as seen.
+func (s *MemorySeenStore) Seen(id int) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.ids[id]
+	return ok, nil
+}
+
+// MarkSeen marks ids as seen, and returns the ones not seen before.
+func (s *MemorySeenStore) MarkSeen(ids ...int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var added []int
+	for _, id := range ids {
+		if _, ok := s.ids[id]; !ok {
+			s.ids[id] = struct{}{}
+			added = append(added, id)
+		}
+	}
+	return added, nil
+}
+
+// FileSeenStore keeps seen IDs in memory and appends new ones to a file,
+// one ID per line.
+// It can be shared by watchers in one process, but not between processes,
+// since each process only reads the file once when opening it.
+type FileSeenStore struct {
+	mem *MemorySeenStore
+	mu  sync.Mutex
+	f   *os.File
+}
+
+// NewFileSeenStore loads seen IDs from the file at path, creating it if not exists.
+func NewFileSeenStore(path string) (*FileSeenStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileSeenStore{mem: NewMemorySeenStore(), f: f}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		id, err := strconv.Atoi(line)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("pixiv: seen store: %s: %w", path, err)
+		}
+		s.mem.ids[id] = struct{}{}
+	}
+	if err := sc.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// Terminate a torn or hand-edited last line,
+	// so that the next ID is not appended to it.
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.Size() > 0 {
+		b := make([]byte, 1)
+		_, err := f.ReadAt(b, fi.Size()-1)
+		if err == nil && b[0] != '\n' {
+			_, err = f.Write([]byte{'\n'})
+		}
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Seen checks if id has been marked as seen.
+func (s *FileSeenStore) Seen(id int) (bool, error) {
+	return s.mem.Seen(id)
+}
+
+// MarkSeen marks ids as seen, appends the ones not seen before to the file
+// and returns them.
+func (s *FileSeenStore) MarkSeen(ids ...int) ([]int, error) {
+	// Only MarkSeen changes mem, and it is serialized by mu,
+	// so Seen then mem.MarkSeen is atomic here.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := &strings.Builder{}
+	var added []int
+	written := map[int]struct{}{}
+	for _, id := range ids {
+		_, dup := written[id]
+		if ok, _ := s.mem.Seen(id); !ok && !dup {
+			b.WriteString(strconv.Itoa(id))
+			b.WriteByte('\n')
+			written[id] = struct{}{}
+			added = append(added, id)
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	_, err := s.f.WriteString(b.String())
+	if err != nil {
+		return nil, err
+	}
+	return s.mem.MarkSeen(added...)
+}
+
+// Close closes the file.
+func (s *FileSeenStore) Close() error {
+	return s.f.Close()
+}
+
+// SQLiteSeenStore keeps seen IDs in a table of SQLite database.
+// It works with any SQLite driver of database/sql.
+type SQLiteSeenStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLiteSeenStore creates the table if not exists and returns the store.
+// table is put into SQL statements as is, so it must be a trusted name.
+func NewSQLiteSeenStore(db *sql.DB, table string) (*SQLiteSeenStore, error) {
+	_, err := db.Exec("CREATE TABLE IF NOT EXISTS " + table + " (id INTEGER PRIMARY KEY)")
+	if err != nil {
+		return nil, err
+	}
+	return &SQLiteSeenStore{db: db, table: table}, nil
+}
+
+// Seen checks if id has been marked as seen.
+func (s *SQLiteSeenStore) Seen(id int) (bool, error) {
+	var n int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM "+s.table+" WHERE id = ?", id).Scan(&n)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// MarkSeen marks ids as seen in one transaction, and returns the ones not seen before.
+// It can be shared between processes using the same database.
+func (s *SQLiteSeenStore) MarkSeen(ids ...int) ([]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	var added []int
+	for _, id := range ids {
+		res, err := tx.Exec("INSERT OR IGNORE INTO "+s.table+" (id) VALUES (?)", id)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if n > 0 {
+			added = append(added, id)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// Watcher polls new illusts from followings and returns the unseen ones.
+type Watcher struct {
+	api *AppAPI
+
+	Restrict Restrict
+	Store    SeenStore
+
+	// MaxPages limits the pages fetched in one poll. Defaults to 1.
+	MaxPages int
+}
+
+// NewWatcher returns a Watcher of followings with restrict.
+// If store is nil, a MemorySeenStore is used.
+func NewWatcher(api *AppAPI, restrict Restrict, store SeenStore) *Watcher {
+	if store == nil {
+		store = NewMemorySeenStore()
+	}
+	return &Watcher{api: api, Restrict: restrict, Store: store}
+}
+
+// Poll fetches new illusts from followings, marks them as seen,
+// and returns the ones not seen before in the order of the feed.
+// It stops fetching next pages when a seen illust is met.
+// An illust is returned by only one of the watchers sharing a store.
+func (w *Watcher) Poll() ([]*Illust, error) {
+	maxPages := w.MaxPages
+	if maxPages <= 0 {
+		maxPages = 1
+	}
+
+	r, err := w.api.Illust.NewFromFollowings(w.Restrict)
+	if err != nil {
+		return nil, err
+	}
+
+	var unseen []*Illust
+	for page := 1; ; page++ {
+		// Skip duplicates in the page, so that they are not taken as seen.
+		ids := make([]int, 0, len(r.Illusts))
+		inPage := make(map[int]bool, len(r.Illusts))
+		for _, il := range r.Illusts {
+			if !inPage[il.ID] {
+				inPage[il.ID] = true
+				ids = append(ids, il.ID)
+			}
+		}
+		added, err := w.Store.MarkSeen(ids...)
+		if err != nil {
+			return nil, err
+		}
+
+		isAdded := make(map[int]bool, len(added))
+		for _, id := range added {
+			isAdded[id] = true
+		}
+		for _, il := range r.Illusts {
+			if isAdded[il.ID] {
+				unseen = append(unseen, il)
+				isAdded[il.ID] = false
+			}
+		}
+
+		met := len(added) < len(ids)
+		if met || page >= maxPages || r.NextURL == "" {
+			break
+		}
+		r, err = r.NextIllusts()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return unseen, nil
+}
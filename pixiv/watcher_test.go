@@ -0,0 +1,276 @@
+package pixiv
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// seenDriver is a fake database/sql driver which only understands
+// the statements used by SQLiteSeenStore.
+type seenDriver struct {
+	mu  sync.Mutex
+	ids map[int64]bool
+}
+
+func (d *seenDriver) Open(name string) (driver.Conn, error) { return &seenConn{d}, nil }
+
+type seenConn struct{ d *seenDriver }
+
+func (c *seenConn) Prepare(query string) (driver.Stmt, error) { return &seenStmt{c.d, query}, nil }
+func (c *seenConn) Close() error                              { return nil }
+func (c *seenConn) Begin() (driver.Tx, error)                 { return c, nil }
+func (c *seenConn) Commit() error                             { return nil }
+func (c *seenConn) Rollback() error                           { return nil }
+
+type seenStmt struct {
+	d     *seenDriver
+	query string
+}
+
+func (s *seenStmt) Close() error  { return nil }
+func (s *seenStmt) NumInput() int { return strings.Count(s.query, "?") }
+
+func (s *seenStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	switch {
+	case strings.HasPrefix(s.query, "CREATE TABLE"):
+		return driver.RowsAffected(0), nil
+	case strings.HasPrefix(s.query, "INSERT OR IGNORE"):
+		id := args[0].(int64)
+		if s.d.ids[id] {
+			return driver.RowsAffected(0), nil
+		}
+		s.d.ids[id] = true
+		return driver.RowsAffected(1), nil
+	}
+	return nil, errors.New("unsupported: " + s.query)
+}
+
+func (s *seenStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if !strings.HasPrefix(s.query, "SELECT COUNT(*)") {
+		return nil, errors.New("unsupported: " + s.query)
+	}
+	var n int64
+	if s.d.ids[args[0].(int64)] {
+		n = 1
+	}
+	return &seenRows{n: n}, nil
+}
+
+type seenRows struct {
+	n    int64
+	done bool
+}
+
+func (r *seenRows) Columns() []string { return []string{"count"} }
+func (r *seenRows) Close() error      { return nil }
+func (r *seenRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.n
+	return nil
+}
+
+// seenDriver is also its own driver.Connector, for a fresh database per test.
+func (d *seenDriver) Connect(context.Context) (driver.Conn, error) { return d.Open("") }
+func (d *seenDriver) Driver() driver.Driver                        { return d }
+
+func testSeenStore(t *testing.T, s SeenStore) {
+	added, err := s.MarkSeen(1, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(len(added) == 2 && added[0] == 1 && added[1] == 2, added)
+
+	added, err = s.MarkSeen(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(len(added) == 1 && added[0] == 3, added)
+
+	ok, _ := s.Seen(3)
+	assert(ok, 3)
+	ok, _ = s.Seen(4)
+	assert(!ok, 4)
+
+	// Each id is only returned by one of the concurrent calls.
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	count := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			added, err := s.MarkSeen(10, 11, 12)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			count += len(added)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert(count == 3, count)
+}
+
+func TestMemorySeenStore(t *testing.T) {
+	testSeenStore(t, NewMemorySeenStore())
+}
+
+func TestSQLiteSeenStore(t *testing.T) {
+	db := sql.OpenDB(&seenDriver{ids: map[int64]bool{}})
+	defer db.Close()
+
+	s, err := NewSQLiteSeenStore(db, "seen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSeenStore(t, s)
+}
+
+func TestFileSeenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pixiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "seen.txt")
+
+	s, err := NewFileSeenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testSeenStore(t, s)
+	s.Close()
+
+	s, err = NewFileSeenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ok, _ := s.Seen(12)
+	assert(ok, 12)
+	ok, _ = s.Seen(4)
+	assert(!ok, 4)
+	added, _ := s.MarkSeen(3, 4)
+	assert(len(added) == 1 && added[0] == 4, added)
+}
+
+func TestFileSeenStoreNoTrailingNewline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-pixiv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "seen.txt")
+	err = ioutil.WriteFile(path, []byte("1\n2"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewFileSeenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.MarkSeen(3)
+	s.Close()
+
+	b, _ := ioutil.ReadFile(path)
+	assert(string(b) == "1\n2\n3\n", string(b))
+}
+
+func TestWatcherPoll(t *testing.T) {
+	var mu sync.Mutex
+	first := `[{"id":5},{"id":4},{"id":4}]`
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			mu.Lock()
+			w.Write([]byte(`{"illusts":` + first + `,"next_url":"` + ts.URL + `/v2/illust/follow?offset=30"}`))
+			mu.Unlock()
+		case "30":
+			w.Write([]byte(`{"illusts":[{"id":3},{"id":2}],"next_url":"` + ts.URL + `/v2/illust/follow?offset=60"}`))
+		default:
+			w.Write([]byte(`{"illusts":[{"id":1}]}`))
+		}
+	}))
+	defer ts.Close()
+
+	api := New()
+	api.BaseURL = ts.URL
+	api.AccessToken = "token"
+	store := NewMemorySeenStore()
+	ws := []*Watcher{NewWatcher(api, RPublic, store), NewWatcher(api, RPublic, store)}
+
+	// Two watchers sharing a store return each illust only once,
+	// limited by MaxPages.
+	var wg sync.WaitGroup
+	results := make([][]*Illust, len(ws))
+	for i, w := range ws {
+		w.MaxPages = 2
+		wg.Add(1)
+		go func(i int, w *Watcher) {
+			defer wg.Done()
+			r, err := w.Poll()
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = r
+		}(i, w)
+	}
+	wg.Wait()
+
+	count := map[int]int{}
+	for _, r := range results {
+		for _, il := range r {
+			count[il.ID]++
+		}
+	}
+	assert(len(count) == 4, count)
+	for _, id := range []int{5, 4, 3, 2} {
+		assert(count[id] == 1, id, count)
+	}
+
+	// Stops at the first seen illust.
+	mu.Lock()
+	first = `[{"id":6},{"id":5}]`
+	mu.Unlock()
+	r, err := ws[0].Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(len(r) == 1 && r[0].ID == 6, r)
+}
+
+func TestWatcher(t *testing.T) {
+	api := getTestAPI(t)
+	w := NewWatcher(api, RPublic, nil)
+	_, err := w.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := w.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(len(r) == 0, len(r))
+}